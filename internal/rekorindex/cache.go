@@ -0,0 +1,173 @@
+package rekorindex
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	genindex "github.com/sigstore/rekor/pkg/generated/client/index"
+)
+
+// WithCache installs an in-process LRU/TTL cache of SearchIndexOK payloads
+// on the returned Client, keyed by a canonical hash of the request's query
+// fields. Concurrent calls for the same key are coalesced into a single
+// HTTP request. The default Client has no cache, so behavior is unchanged
+// unless this option is used.
+func WithCache(ttl time.Duration, capacity int) Option {
+	return func(c *Client) {
+		c.cache = newIndexCache(ttl, capacity)
+	}
+}
+
+// searchIndexCacheKey hashes the fields of a SearchIndex query that
+// determine its result, so that repeated lookups for the same
+// sha/email/publicKey/hash collapse onto the same cache entry.
+func searchIndexCacheKey(params *genindex.SearchIndexParams) string {
+	h := sha256.New()
+	if params != nil {
+		if q := params.Query; q != nil {
+			h.Write([]byte(q.Hash))
+			h.Write([]byte{0})
+			h.Write([]byte(q.Email))
+			h.Write([]byte{0})
+			h.Write([]byte(q.Operator))
+			h.Write([]byte{0})
+			if pk := q.PublicKey; pk != nil {
+				h.Write([]byte(pk.Content))
+				h.Write([]byte{0})
+				if pk.Format != nil {
+					h.Write([]byte(*pk.Format))
+				}
+				h.Write([]byte{0})
+				h.Write([]byte(pk.URL))
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type cacheEntry struct {
+	key     string
+	payload []string
+	expires time.Time
+}
+
+// indexCache is an LRU cache of SearchIndex payloads with a per-entry TTL,
+// plus singleflight-style coalescing of concurrent misses for the same key.
+//
+// Stored and returned payloads are always copied, never shared by
+// reference: cache hits must not hand out the slice backing a live cache
+// entry, or a caller mutating its result would corrupt that entry for
+// every other concurrent reader.
+type indexCache struct {
+	ttl      time.Duration
+	capacity int
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+
+	inflight map[string]*indexCacheCall
+}
+
+// indexCacheCall tracks a single in-flight fetch so that concurrent callers
+// asking for the same key share one underlying request.
+type indexCacheCall struct {
+	done    chan struct{}
+	payload []string
+	err     error
+}
+
+func newIndexCache(ttl time.Duration, capacity int) *indexCache {
+	return &indexCache{
+		ttl:      ttl,
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		inflight: make(map[string]*indexCacheCall),
+	}
+}
+
+// do returns the cached payload for key if present and unexpired. On a
+// miss, it calls fetch exactly once even if multiple goroutines ask for the
+// same key concurrently, and populates the cache with the result. The
+// returned slice is always a copy, safe for the caller to mutate freely.
+func (c *indexCache) do(key string, fetch func() ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	if payload, ok := c.getLocked(key); ok {
+		c.mu.Unlock()
+		return payload, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return copyPayload(call.payload), call.err
+	}
+	call := &indexCacheCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.payload, call.err = fetch()
+	close(call.done)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.setLocked(key, call.payload)
+	}
+	c.mu.Unlock()
+
+	return copyPayload(call.payload), call.err
+}
+
+func (c *indexCache) getLocked(key string) ([]string, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return copyPayload(entry.payload), true
+}
+
+func (c *indexCache) setLocked(key string, payload []string) {
+	stored := copyPayload(payload)
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*cacheEntry).payload = stored
+		el.Value.(*cacheEntry).expires = time.Now().Add(c.ttl)
+		c.order.MoveToFront(el)
+		return
+	}
+
+	entry := &cacheEntry{key: key, payload: stored, expires: time.Now().Add(c.ttl)}
+	el := c.order.PushFront(entry)
+	c.items[key] = el
+
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// copyPayload returns a fresh copy of payload so that callers can never
+// observe or mutate a cache entry's backing array.
+func copyPayload(payload []string) []string {
+	if payload == nil {
+		return nil
+	}
+	out := make([]string, len(payload))
+	copy(out, payload)
+	return out
+}
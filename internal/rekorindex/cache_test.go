@@ -0,0 +1,107 @@
+package rekorindex
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	genindex "github.com/sigstore/rekor/pkg/generated/client/index"
+)
+
+// countingTransport counts how many times Submit is actually invoked, so
+// tests can assert on cache hits vs. real HTTP calls.
+type countingTransport struct {
+	calls   int64
+	payload []string
+	delay   time.Duration
+}
+
+func (t *countingTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	atomic.AddInt64(&t.calls, 1)
+	if t.delay > 0 {
+		time.Sleep(t.delay)
+	}
+	return &genindex.SearchIndexOK{Payload: t.payload}, nil
+}
+
+func TestCacheHit(t *testing.T) {
+	transport := &countingTransport{payload: []string{"uuid-1"}}
+	client := New(transport, strfmt.Default, WithCache(time.Minute, 10))
+
+	params := genindex.NewSearchIndexParams()
+	if _, err := client.SearchIndex(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SearchIndex(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt64(&transport.calls); got != 1 {
+		t.Fatalf("expected 1 HTTP call for a cache hit, got %d", got)
+	}
+}
+
+func TestCacheTTLExpiry(t *testing.T) {
+	transport := &countingTransport{payload: []string{"uuid-1"}}
+	client := New(transport, strfmt.Default, WithCache(10*time.Millisecond, 10))
+
+	params := genindex.NewSearchIndexParams()
+	if _, err := client.SearchIndex(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := client.SearchIndex(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt64(&transport.calls); got != 2 {
+		t.Fatalf("expected 2 HTTP calls after TTL expiry, got %d", got)
+	}
+}
+
+func TestCacheCoalescesConcurrentCallers(t *testing.T) {
+	transport := &countingTransport{payload: []string{"uuid-1"}, delay: 20 * time.Millisecond}
+	client := New(transport, strfmt.Default, WithCache(time.Minute, 10))
+
+	params := genindex.NewSearchIndexParams()
+	const n = 20
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := client.SearchIndex(params); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt64(&transport.calls); got != 1 {
+		t.Fatalf("expected exactly 1 HTTP call for %d concurrent identical requests, got %d", n, got)
+	}
+}
+
+func TestCacheReturnsIndependentCopies(t *testing.T) {
+	transport := &countingTransport{payload: []string{"uuid-1"}}
+	client := New(transport, strfmt.Default, WithCache(time.Minute, 10))
+
+	params := genindex.NewSearchIndexParams()
+	first, err := client.SearchIndex(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Payload[0] = "mutated"
+
+	second, err := client.SearchIndex(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Payload[0] != "uuid-1" {
+		t.Fatalf("mutating one caller's result corrupted the shared cache entry: got %q", second.Payload[0])
+	}
+}
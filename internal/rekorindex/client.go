@@ -0,0 +1,132 @@
+// Package rekorindex wraps the vendored Rekor index API client
+// (github.com/sigstore/rekor/pkg/generated/client/index) with the
+// resilience and performance behavior CRI-O needs around SearchIndex:
+// configurable transport schemes, retries with a circuit breaker, result
+// caching, and streaming reads.
+//
+// This logic intentionally lives here rather than patched into the
+// vendored client, so that re-vendoring Rekor (go mod vendor, or bumping
+// the dependency) can never silently drop it.
+package rekorindex
+
+import (
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	genindex "github.com/sigstore/rekor/pkg/generated/client/index"
+)
+
+// Client wraps a generated Rekor index client, adding CRI-O's scheme,
+// retry, caching and streaming behavior around it.
+type Client struct {
+	inner     genindex.ClientService
+	transport runtime.ClientTransport
+	formats   strfmt.Registry
+	schemes   []string
+
+	retry   *RetryPolicy
+	breaker *circuitBreaker
+	metrics metricsCounters
+
+	cache *indexCache
+}
+
+// Option configures a Client at construction time.
+type Option func(*Client)
+
+// New creates a Client around the given transport, using whatever scheme
+// the generated client defaults to (plain HTTP) unless overridden with
+// WithSchemes.
+func New(transport runtime.ClientTransport, formats strfmt.Registry, opts ...Option) *Client {
+	c := &Client{
+		inner:     genindex.New(transport, formats),
+		transport: transport,
+		formats:   formats,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewHTTPS creates a Client that issues requests over HTTPS, for talking to
+// a TLS-terminated Rekor instance. The scheme can still be overridden with
+// WithSchemes.
+func NewHTTPS(transport runtime.ClientTransport, formats strfmt.Registry, opts ...Option) *Client {
+	c := New(transport, formats, opts...)
+	if c.schemes == nil {
+		c.schemes = []string{"https"}
+	}
+	return c
+}
+
+// WithSchemes sets the transport schemes (e.g. "https") used for every
+// SearchIndex call made through the resulting Client.
+func WithSchemes(schemes ...string) Option {
+	return func(c *Client) {
+		c.schemes = schemes
+	}
+}
+
+// SearchIndex searches the index by entry metadata, via the wrapped
+// generated client, honoring the Client's retry policy and result cache
+// (if configured).
+func (c *Client) SearchIndex(params *genindex.SearchIndexParams, opts ...genindex.ClientOption) (*genindex.SearchIndexOK, error) {
+	fetch := func() (*genindex.SearchIndexOK, error) {
+		return c.searchIndexWithPolicy(nil, params, opts...)
+	}
+
+	if c.cache == nil {
+		return fetch()
+	}
+
+	payload, err := c.cache.do(searchIndexCacheKey(params), func() ([]string, error) {
+		result, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		return result.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &genindex.SearchIndexOK{Payload: payload}, nil
+}
+
+// SearchIndexWithRetry behaves like SearchIndex, but uses policy instead of
+// the Client's configured retry policy (if any) for this call only. Unlike
+// SearchIndex, it neither reads from nor populates the result cache.
+func (c *Client) SearchIndexWithRetry(params *genindex.SearchIndexParams, policy *RetryPolicy, opts ...genindex.ClientOption) (*genindex.SearchIndexOK, error) {
+	return c.searchIndexWithPolicy(policy, params, opts...)
+}
+
+func (c *Client) searchIndexWithPolicy(policy *RetryPolicy, params *genindex.SearchIndexParams, opts ...genindex.ClientOption) (*genindex.SearchIndexOK, error) {
+	var result *genindex.SearchIndexOK
+	err := c.withRetry(policy, func() error {
+		var err error
+		result, err = c.inner.SearchIndex(params, c.callOpts(opts)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// callOpts appends the Client's configured scheme override (if any) to the
+// per-call options passed by the caller.
+func (c *Client) callOpts(opts []genindex.ClientOption) []genindex.ClientOption {
+	if len(c.schemes) == 0 {
+		return opts
+	}
+	schemes := c.schemes
+	return append(opts, func(op *runtime.ClientOperation) {
+		op.Schemes = schemes
+	})
+}
+
+// SetTransport changes the transport on the wrapped client.
+func (c *Client) SetTransport(transport runtime.ClientTransport) {
+	c.inner.SetTransport(transport)
+	c.transport = transport
+}
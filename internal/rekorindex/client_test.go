@@ -0,0 +1,60 @@
+package rekorindex
+
+import (
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	genindex "github.com/sigstore/rekor/pkg/generated/client/index"
+)
+
+// recordingTransport captures the scheme list a ClientOperation was
+// submitted with, so tests can assert on it without a real HTTP server.
+type recordingTransport struct {
+	schemes []string
+}
+
+func (t *recordingTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	t.schemes = op.Schemes
+	return &genindex.SearchIndexOK{Payload: []string{"uuid-1"}}, nil
+}
+
+func TestNewHTTPSUsesHTTPSScheme(t *testing.T) {
+	transport := &recordingTransport{}
+	client := NewHTTPS(transport, strfmt.Default)
+
+	if _, err := client.SearchIndex(genindex.NewSearchIndexParams()); err != nil {
+		t.Fatalf("SearchIndex returned error: %v", err)
+	}
+
+	if got := transport.schemes; len(got) != 1 || got[0] != "https" {
+		t.Fatalf("expected schemes [https], got %v", got)
+	}
+}
+
+func TestWithSchemesOverridesDefault(t *testing.T) {
+	transport := &recordingTransport{}
+	client := New(transport, strfmt.Default, WithSchemes("https"))
+
+	if _, err := client.SearchIndex(genindex.NewSearchIndexParams()); err != nil {
+		t.Fatalf("SearchIndex returned error: %v", err)
+	}
+
+	if got := transport.schemes; len(got) != 1 || got[0] != "https" {
+		t.Fatalf("expected schemes [https], got %v", got)
+	}
+}
+
+func TestNewDefaultsToGeneratedClientScheme(t *testing.T) {
+	transport := &recordingTransport{}
+	client := New(transport, strfmt.Default)
+
+	if _, err := client.SearchIndex(genindex.NewSearchIndexParams()); err != nil {
+		t.Fatalf("SearchIndex returned error: %v", err)
+	}
+
+	if got := transport.schemes; len(got) != 1 || got[0] != "http" {
+		t.Fatalf("expected schemes [http] (generated client default), got %v", got)
+	}
+}
@@ -0,0 +1,222 @@
+package rekorindex
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy configures exponential backoff retries for calls made through
+// a Client. Index lookups sit on the hot path of container startup, so a
+// transient 5xx or network blip against Rekor should not fail an otherwise
+// good image pull.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values <= 1 disable retries.
+	MaxAttempts int
+	// Base is the initial backoff between the first and second attempt.
+	Base time.Duration
+	// Max caps the backoff between any two attempts.
+	Max time.Duration
+	// Jitter randomizes each backoff in the range [0, backoff) instead of
+	// sleeping the full computed duration.
+	Jitter bool
+}
+
+// WithRetry sets the default retry policy used by every call made through
+// the returned Client, backed by a circuit breaker that trips once
+// retryable failures pile up within a rolling window.
+func WithRetry(maxAttempts int, base, max time.Duration, jitter bool) Option {
+	policy := &RetryPolicy{MaxAttempts: maxAttempts, Base: base, Max: max, Jitter: jitter}
+	return func(c *Client) {
+		c.retry = policy
+		c.breaker = newCircuitBreaker(defaultBreakerThreshold, defaultBreakerWindow, defaultBreakerCooldown)
+	}
+}
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerWindow    = 30 * time.Second
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// Metrics reports counters for retry and circuit breaker activity on a
+// Client. It is safe to read concurrently with in-flight calls.
+type Metrics struct {
+	Attempts     uint64
+	Retries      uint64
+	BreakerOpens uint64
+}
+
+// Metrics returns a snapshot of the Client's retry and circuit breaker
+// counters.
+func (c *Client) Metrics() Metrics {
+	return Metrics{
+		Attempts:     atomic.LoadUint64(&c.metrics.attempts),
+		Retries:      atomic.LoadUint64(&c.metrics.retries),
+		BreakerOpens: atomic.LoadUint64(&c.metrics.breakerOpens),
+	}
+}
+
+type metricsCounters struct {
+	attempts     uint64
+	retries      uint64
+	breakerOpens uint64
+}
+
+// withRetry runs fn according to policy (falling back to c.retry if policy
+// is nil), wrapping it with the Client's circuit breaker. With no policy
+// configured at all, fn runs exactly once. fn reports its result through
+// closed-over state rather than a return value, so the same retry loop
+// covers both SearchIndex (which produces a payload) and SearchIndexStream
+// (which only produces an error).
+func (c *Client) withRetry(policy *RetryPolicy, fn func() error) error {
+	if policy == nil {
+		policy = c.retry
+	}
+	if policy == nil {
+		atomic.AddUint64(&c.metrics.attempts, 1)
+		return fn()
+	}
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	breaker := c.breaker
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if breaker != nil && !breaker.Allow(time.Now()) {
+			atomic.AddUint64(&c.metrics.breakerOpens, 1)
+			if lastErr != nil {
+				return lastErr
+			}
+			return errCircuitOpen
+		}
+
+		if attempt > 0 {
+			atomic.AddUint64(&c.metrics.retries, 1)
+			if wait := backoff(policy, attempt); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+
+		atomic.AddUint64(&c.metrics.attempts, 1)
+		err := fn()
+		if err == nil {
+			if breaker != nil {
+				breaker.RecordSuccess()
+			}
+			return nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			// Malformed requests and other non-retryable errors are the
+			// caller's fault, not Rekor's: don't let them count toward
+			// tripping the breaker for every other caller.
+			return err
+		}
+		if breaker != nil {
+			breaker.RecordFailure(time.Now())
+		}
+	}
+	return lastErr
+}
+
+func backoff(policy *RetryPolicy, attempt int) time.Duration {
+	d := policy.Base << uint(attempt-1)
+	if d <= 0 || d > policy.Max {
+		d = policy.Max
+	}
+	if policy.Jitter && d > 0 {
+		d = time.Duration(rand.Int63n(int64(d)))
+	}
+	return d
+}
+
+// statusCoder is implemented by the generated client's error responses
+// (e.g. index.SearchIndexDefault) and by the stream reader's status error.
+// go-openapi's runtime.APIError keeps its status in a Code field, not a
+// method, so it does not implement statusCoder.
+type statusCoder interface {
+	Code() int
+}
+
+// isRetryable reports whether err looks like a transient failure worth
+// retrying: a network error (no status code at all), or a 429/5xx
+// response. Other 4xx responses (e.g. a malformed query) are the caller's
+// fault and are never retried.
+func isRetryable(err error) bool {
+	sc, ok := err.(statusCoder)
+	if !ok {
+		// No status code: a network-level error (timeout, connection
+		// reset, DNS failure, ...), which is transient by nature.
+		return true
+	}
+	switch sc.Code() {
+	case 429:
+		return true
+	default:
+		return sc.Code() >= 500
+	}
+}
+
+var errCircuitOpen = &circuitOpenError{}
+
+type circuitOpenError struct{}
+
+func (*circuitOpenError) Error() string {
+	return "rekorindex: circuit breaker open, short-circuiting SearchIndex call"
+}
+
+// circuitBreaker trips once failureThreshold retryable failures land
+// within window, and stays open for cooldown before allowing calls through
+// again.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	window           time.Duration
+	cooldown         time.Duration
+	failures         []time.Time
+	openUntil        time.Time
+}
+
+func newCircuitBreaker(failureThreshold int, window, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		window:           window,
+		cooldown:         cooldown,
+	}
+}
+
+func (b *circuitBreaker) Allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return now.After(b.openUntil)
+}
+
+func (b *circuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = b.failures[:0]
+}
+
+func (b *circuitBreaker) RecordFailure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cutoff := now.Add(-b.window)
+	kept := b.failures[:0]
+	for _, t := range b.failures {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	b.failures = append(kept, now)
+
+	if len(b.failures) >= b.failureThreshold {
+		b.openUntil = now.Add(b.cooldown)
+		b.failures = b.failures[:0]
+	}
+}
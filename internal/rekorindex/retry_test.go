@@ -0,0 +1,77 @@
+package rekorindex
+
+import (
+	"testing"
+	"time"
+)
+
+// codedError mimics the shape of go-openapi's runtime.APIError: an error
+// that reports an HTTP status code.
+type codedError struct{ code int }
+
+func (e *codedError) Error() string { return "status error" }
+func (e *codedError) Code() int     { return e.code }
+
+func TestWithRetryRetriesOnRetryableError(t *testing.T) {
+	c := &Client{
+		retry:   &RetryPolicy{MaxAttempts: 3, Base: time.Millisecond, Max: time.Millisecond},
+		breaker: newCircuitBreaker(defaultBreakerThreshold, defaultBreakerWindow, defaultBreakerCooldown),
+	}
+
+	attempts := 0
+	err := c.withRetry(nil, func() error {
+		attempts++
+		if attempts < 3 {
+			return &codedError{code: 503}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestNonRetryableErrorIsNotRetriedOrBreakerTripped(t *testing.T) {
+	c := &Client{
+		retry:   &RetryPolicy{MaxAttempts: 3, Base: time.Millisecond, Max: time.Millisecond},
+		breaker: newCircuitBreaker(1, defaultBreakerWindow, time.Hour),
+	}
+
+	attempts := 0
+	for i := 0; i < 5; i++ {
+		err := c.withRetry(nil, func() error {
+			attempts++
+			return &codedError{code: 400}
+		})
+		if err == nil {
+			t.Fatalf("expected error for a 400 response")
+		}
+	}
+
+	if attempts != 5 {
+		t.Fatalf("expected no retries for non-retryable errors, got %d attempts for 5 calls", attempts)
+	}
+	if !c.breaker.Allow(time.Now()) {
+		t.Fatalf("breaker should not trip on non-retryable (400) errors")
+	}
+}
+
+func TestRetryableErrorTripsBreaker(t *testing.T) {
+	c := &Client{
+		retry:   &RetryPolicy{MaxAttempts: 2, Base: time.Millisecond, Max: time.Millisecond},
+		breaker: newCircuitBreaker(1, defaultBreakerWindow, time.Hour),
+	}
+
+	err := c.withRetry(nil, func() error {
+		return &codedError{code: 503}
+	})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if c.breaker.Allow(time.Now()) {
+		t.Fatalf("breaker should have tripped after a retryable failure crossed the threshold")
+	}
+}
@@ -0,0 +1,128 @@
+package rekorindex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	genindex "github.com/sigstore/rekor/pkg/generated/client/index"
+)
+
+// ErrStopSearchIndexStream can be returned by a SearchIndexStream callback
+// to stop iteration early without the error being propagated to the
+// caller, e.g. once a signature verifier has found the entry it needed.
+var ErrStopSearchIndexStream = errors.New("rekorindex: stop search index stream")
+
+// SearchIndexStream behaves like SearchIndex, but invokes fn once per UUID
+// as it is decoded off the wire instead of materializing the full result
+// slice in memory first. This bounds memory use for popular keys/images
+// that can have very large result sets, and lets a caller stop as soon as
+// it has seen a matching entry by returning ErrStopSearchIndexStream.
+//
+// SearchIndexStream talks to the transport directly rather than going
+// through the generated client's SearchIndex, since that method always
+// decodes the full response body into a single []string before returning.
+// It bypasses the result cache installed via WithCache, since its whole
+// point is to avoid holding the full payload at once; it does honor the
+// Client's retry policy and circuit breaker. Because a retry resubmits
+// the whole request and re-decodes the body from the start, UUIDs already
+// delivered to fn before a mid-stream failure are tracked for the
+// lifetime of this call and are not passed to fn again on a retry.
+func (c *Client) SearchIndexStream(params *genindex.SearchIndexParams, fn func(uuid string) error, opts ...genindex.ClientOption) error {
+	if fn == nil {
+		return errors.New("rekorindex: SearchIndexStream requires a non-nil callback")
+	}
+	if params == nil {
+		params = genindex.NewSearchIndexParams()
+	}
+
+	delivered := make(map[string]struct{})
+	var fnErr error
+	dedupedFn := func(uuid string) error {
+		if _, ok := delivered[uuid]; ok {
+			return nil
+		}
+		delivered[uuid] = struct{}{}
+		err := fn(uuid)
+		if err != nil && err != ErrStopSearchIndexStream {
+			fnErr = err
+		}
+		return err
+	}
+
+	op := &runtime.ClientOperation{
+		ID:                 "searchIndex",
+		Method:             "POST",
+		PathPattern:        "/api/v1/index/retrieve",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &searchIndexStreamReader{fn: dedupedFn},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range c.callOpts(opts) {
+		opt(op)
+	}
+
+	err := c.withRetry(nil, func() error {
+		_, err := c.transport.Submit(op)
+		if fnErr != nil {
+			// The callback failed, not Rekor: stop without retrying.
+			return nil
+		}
+		return err
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
+// searchIndexStreamReader implements runtime.ClientResponseReader, decoding
+// the SearchIndex response body one UUID at a time as bytes arrive rather
+// than unmarshaling it into a single []string.
+type searchIndexStreamReader struct {
+	fn func(uuid string) error
+}
+
+// searchIndexStreamStatusError reports a non-200 response to a
+// SearchIndexStream request, exposing its status code to isRetryable.
+type searchIndexStreamStatusError struct{ code int }
+
+func (e *searchIndexStreamStatusError) Error() string {
+	return fmt.Sprintf("rekorindex: unexpected status %d from searchIndex stream", e.code)
+}
+
+func (e *searchIndexStreamStatusError) Code() int { return e.code }
+
+func (r *searchIndexStreamReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	if response.Code() != 200 {
+		return nil, &searchIndexStreamStatusError{code: response.Code()}
+	}
+
+	dec := json.NewDecoder(response.Body())
+	if _, err := dec.Token(); err != nil { // opening '['
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for dec.More() {
+		var uuid string
+		if err := dec.Decode(&uuid); err != nil {
+			return nil, err
+		}
+		if err := r.fn(uuid); err != nil {
+			if err == ErrStopSearchIndexStream {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+	return nil, nil
+}
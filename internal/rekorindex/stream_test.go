@@ -0,0 +1,142 @@
+package rekorindex
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+
+	genindex "github.com/sigstore/rekor/pkg/generated/client/index"
+)
+
+// fakeClientResponse implements runtime.ClientResponse over an in-memory
+// body, standing in for what the real HTTP transport would hand the
+// configured Reader.
+type fakeClientResponse struct {
+	code int
+	body io.ReadCloser
+}
+
+func (r *fakeClientResponse) Code() int                  { return r.code }
+func (r *fakeClientResponse) Message() string            { return "" }
+func (r *fakeClientResponse) GetHeader(string) string    { return "" }
+func (r *fakeClientResponse) GetHeaders(string) []string { return nil }
+func (r *fakeClientResponse) Body() io.ReadCloser        { return r.body }
+
+// streamingTransport drives op.Reader directly, the way the real
+// go-openapi HTTP transport would after receiving a response.
+type streamingTransport struct {
+	body string
+}
+
+func (t *streamingTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	resp := &fakeClientResponse{code: 200, body: io.NopCloser(bytes.NewBufferString(t.body))}
+	return op.Reader.ReadResponse(resp, nil)
+}
+
+func TestSearchIndexStreamInvokesCallbackPerUUID(t *testing.T) {
+	transport := &streamingTransport{body: `["uuid-1","uuid-2","uuid-3"]`}
+	client := New(transport, nil)
+
+	var got []string
+	err := client.SearchIndexStream(genindex.NewSearchIndexParams(), func(uuid string) error {
+		got = append(got, uuid)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"uuid-1", "uuid-2", "uuid-3"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSearchIndexStreamStopsEarly(t *testing.T) {
+	transport := &streamingTransport{body: `["uuid-1","uuid-2","uuid-3"]`}
+	client := New(transport, nil)
+
+	var got []string
+	err := client.SearchIndexStream(genindex.NewSearchIndexParams(), func(uuid string) error {
+		got = append(got, uuid)
+		if uuid == "uuid-2" {
+			return ErrStopSearchIndexStream
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected ErrStopSearchIndexStream to be swallowed, got: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected iteration to stop after uuid-2, got %v", got)
+	}
+}
+
+func TestSearchIndexStreamRequiresCallback(t *testing.T) {
+	transport := &streamingTransport{body: `[]`}
+	client := New(transport, nil)
+
+	if err := client.SearchIndexStream(genindex.NewSearchIndexParams(), nil); err == nil {
+		t.Fatalf("expected an error for a nil callback")
+	}
+}
+
+// errAfterReader fails every Read with err. Placed after a prefix in an
+// io.MultiReader, it simulates a connection that drops mid-response.
+type errAfterReader struct {
+	err error
+}
+
+func (r *errAfterReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// flakyStreamingTransport fails mid-stream on its first Submit, after
+// delivering one UUID, then succeeds with the full body on the next
+// attempt, the way a transient network blip against Rekor would look.
+type flakyStreamingTransport struct {
+	calls int
+}
+
+func (t *flakyStreamingTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	t.calls++
+	var body io.ReadCloser
+	if t.calls == 1 {
+		body = io.NopCloser(io.MultiReader(strings.NewReader(`["uuid-1",`), &errAfterReader{err: io.ErrUnexpectedEOF}))
+	} else {
+		body = io.NopCloser(bytes.NewBufferString(`["uuid-1","uuid-2","uuid-3"]`))
+	}
+	resp := &fakeClientResponse{code: 200, body: body}
+	return op.Reader.ReadResponse(resp, nil)
+}
+
+func TestSearchIndexStreamDoesNotReplayUUIDsAcrossRetries(t *testing.T) {
+	transport := &flakyStreamingTransport{}
+	client := New(transport, nil, WithRetry(2, time.Millisecond, time.Millisecond, false))
+
+	var got []string
+	err := client.SearchIndexStream(genindex.NewSearchIndexParams(), func(uuid string) error {
+		got = append(got, uuid)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"uuid-1", "uuid-2", "uuid-3"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v (uuid-1 should not be delivered twice across the retry)", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}